@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/go-daq/canbus"
 	"github.com/golang/geo/r3"
 	goutils "go.viam.com/utils"
+	"golang.org/x/sync/errgroup"
 
 	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/components/generic"
@@ -35,11 +37,24 @@ func init() {
 		registry.Component{Constructor: func(
 			ctx context.Context,
 			deps registry.Dependencies,
-			config config.Component,
+			conf config.Component,
 			logger golog.Logger,
 		) (interface{}, error) {
-			return newBase(config.Name, logger)
+			return newBase(conf, logger)
 		}})
+
+	config.RegisterComponentAttributeMapConverter(
+		base.Subtype,
+		model,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var conf Config
+			if err := utils.TransformAttributeMapToStruct(&conf, attributes); err != nil {
+				return nil, err
+			}
+			return &conf, nil
+		},
+		&Config{},
+	)
 }
 
 func main() {
@@ -64,37 +79,204 @@ func mainWithArgs(ctx context.Context, args []string, logger golog.Logger) (err
 	return nil
 }
 
-// newBase creates a new base that underneath the hood sends canbus frames via
-// a 10ms publishing loop.
-func newBase(name string, logger golog.Logger) (base.Base, error) {
+// Config configures an intermode base component: the CAN channel to bind, the frame
+// IDs and scalars for this firmware revision, and the safety limits to enforce.
+type Config struct {
+	// Channel is the SocketCAN interface to bind, e.g. "can0". Leave Channel empty and
+	// set Virtual to target a vcan0 loopback interface (`ip link add vcan0 type vcan`)
+	// for tests and CI; Virtual is ignored when Channel is set.
+	Channel string `json:"channel,omitempty"`
+	Virtual bool   `json:"virtual,omitempty"`
+
+	DriveFrameID  uint32 `json:"drive_frame_id,omitempty"`
+	AuxFrameID    uint32 `json:"aux_frame_id,omitempty"`
+	StatusFrameID uint32 `json:"status_frame_id,omitempty"`
+	FaultFrameID  uint32 `json:"fault_frame_id,omitempty"`
+
+	SteeringAngleScalar    float64 `json:"steering_angle_scalar,omitempty"`
+	SteeringFeedbackScalar float64 `json:"steering_feedback_scalar,omitempty"`
+	AccelScalar            float64 `json:"accel_scalar,omitempty"`
+	WheelSpeedScalar       float64 `json:"wheel_speed_scalar,omitempty"`
+
+	MaxSteeringAngleDeg float64 `json:"max_steering_angle_deg,omitempty"`
+	MaxSpeedPct         float64 `json:"max_speed_pct,omitempty"`
+}
+
+// defaults from the data sheet; overridable via the config above so other Intermode
+// firmware revisions can be supported without recompiling.
+const (
+	defaultChannel        = "can0"
+	defaultVirtualChannel = "vcan0"
+
+	defaultDriveID  uint32 = 0x220
+	defaultAuxID    uint32 = 0x230
+	defaultStatusID uint32 = 0x221
+	defaultFaultID  uint32 = 0x222
+
+	defaultSteeringAngleScalar = 0.0078125 // intermode scalar for the 2-byte commanded angle
+	// the status frame only has a single byte for actual steering angle feedback
+	// (int8, -128..127), so it gets its own coarser scalar sized to the full ±90
+	// degree range instead of reusing the 2-byte command scalar, which would clamp
+	// the decoded angle to ~±1 degree.
+	defaultSteeringFeedbackScalar = 90.0 / 127.0
+	defaultAccelScalar            = 0.0625 // intermode scalar
+	defaultWheelSpeedScalar       = 0.01   // mm/s per LSB
+
+	defaultMaxSteeringAngleDeg = 90.0
+	defaultMaxSpeedPct         = 20.0 // equivalent to the original hardcoded /5 safety nerf
+)
+
+// Validate validates the config and fills in defaults for any unset fields.
+func (cfg *Config) Validate(path string) ([]string, error) {
+	if cfg.Channel == "" {
+		if cfg.Virtual {
+			cfg.Channel = defaultVirtualChannel
+		} else {
+			cfg.Channel = defaultChannel
+		}
+	}
+	if cfg.DriveFrameID == 0 {
+		cfg.DriveFrameID = defaultDriveID
+	}
+	if cfg.AuxFrameID == 0 {
+		cfg.AuxFrameID = defaultAuxID
+	}
+	if cfg.StatusFrameID == 0 {
+		cfg.StatusFrameID = defaultStatusID
+	}
+	if cfg.FaultFrameID == 0 {
+		cfg.FaultFrameID = defaultFaultID
+	}
+	if cfg.SteeringAngleScalar == 0 {
+		cfg.SteeringAngleScalar = defaultSteeringAngleScalar
+	}
+	if cfg.SteeringFeedbackScalar == 0 {
+		cfg.SteeringFeedbackScalar = defaultSteeringFeedbackScalar
+	}
+	if cfg.AccelScalar == 0 {
+		cfg.AccelScalar = defaultAccelScalar
+	}
+	if cfg.WheelSpeedScalar == 0 {
+		cfg.WheelSpeedScalar = defaultWheelSpeedScalar
+	}
+	if cfg.MaxSteeringAngleDeg == 0 {
+		cfg.MaxSteeringAngleDeg = defaultMaxSteeringAngleDeg
+	}
+	if cfg.MaxSteeringAngleDeg < 0 || cfg.MaxSteeringAngleDeg > 90 {
+		return nil, fmt.Errorf("%s: max_steering_angle_deg must be between 0 and 90", path)
+	}
+	if cfg.MaxSpeedPct == 0 {
+		cfg.MaxSpeedPct = defaultMaxSpeedPct
+	}
+	if cfg.MaxSpeedPct < 0 || cfg.MaxSpeedPct > 100 {
+		return nil, fmt.Errorf("%s: max_speed_pct must be between 0 and 100", path)
+	}
+	return nil, nil
+}
+
+// runtimeConfig is the subset of Config the hot send/receive paths need, snapshotted
+// once per generation so they can be read without locking.
+type runtimeConfig struct {
+	driveID  uint32
+	auxID    uint32
+	statusID uint32
+	faultID  uint32
+
+	steeringAngleScalar    float64
+	steeringFeedbackScalar float64
+	accelScalar            float64
+	wheelSpeedScalar       float64
+	maxSteeringAngleDeg    float64
+	maxSpeedPct            float64
+}
+
+func newRuntimeConfig(cfg *Config) *runtimeConfig {
+	return &runtimeConfig{
+		driveID:                cfg.DriveFrameID,
+		auxID:                  cfg.AuxFrameID,
+		statusID:               cfg.StatusFrameID,
+		faultID:                cfg.FaultFrameID,
+		steeringAngleScalar:    cfg.SteeringAngleScalar,
+		steeringFeedbackScalar: cfg.SteeringFeedbackScalar,
+		accelScalar:            cfg.AccelScalar,
+		wheelSpeedScalar:       cfg.WheelSpeedScalar,
+		maxSteeringAngleDeg:    cfg.MaxSteeringAngleDeg,
+		maxSpeedPct:            cfg.MaxSpeedPct,
+	}
+}
+
+// newGeneration binds a CAN socket for the given config and starts its publish/receive
+// background workers, returning the resulting generation for newBase/Reconfigure to
+// install on an interModeBase.
+func newGeneration(intermodeConfig *Config, logger golog.Logger) (*generation, error) {
 	socket, err := canbus.New()
 	if err != nil {
 		return nil, err
 	}
-	if err := socket.Bind(channel); err != nil {
+	if err := socket.Bind(intermodeConfig.Channel); err != nil {
 		return nil, err
 	}
 
+	rtCfg := newRuntimeConfig(intermodeConfig)
 	cancelCtx, cancel := context.WithCancel(context.Background())
-	iBase := &interModeBase{
-		name:          name,
-		nextCommandCh: make(chan canbus.Frame),
-		cancel:        cancel,
-		logger:        logger,
+
+	gen := &generation{
+		cfg:              rtCfg,
+		socket:           socket,
+		nextCommandCh:    make(chan canbus.Frame),
+		nextAuxCommandCh: make(chan canbus.Frame),
+		cancel:           cancel,
+		workers:          &sync.WaitGroup{},
+		state:            &baseState{},
+		drive:            &driveState{},
+		aux:              &auxState{},
+	}
+	gen.diagnostics = map[uint32]*frameDiagnostics{
+		rtCfg.driveID: {},
+		rtCfg.auxID:   {},
 	}
 
-	iBase.activeBackgroundWorkers.Add(1)
+	slots := []frameSlot{
+		{
+			id: rtCfg.driveID, interval: 10 * time.Millisecond, cmdCh: gen.nextCommandCh,
+			initialFrame: stopCmd.toFrame(logger, rtCfg), diag: gen.diagnostics[rtCfg.driveID],
+		},
+		{
+			id: rtCfg.auxID, interval: 100 * time.Millisecond, cmdCh: gen.nextAuxCommandCh,
+			initialFrame: (&auxCommand{}).toFrame(logger, rtCfg), diag: gen.diagnostics[rtCfg.auxID],
+		},
+	}
+
+	gen.workers.Add(1)
 	goutils.ManagedGo(func() {
-		publishThread(cancelCtx, *socket, iBase.nextCommandCh, logger)
-	}, iBase.activeBackgroundWorkers.Done)
-	return iBase, nil
+		publishThread(cancelCtx, *socket, slots, logger)
+	}, gen.workers.Done)
+
+	gen.workers.Add(1)
+	goutils.ManagedGo(func() {
+		receiveThread(cancelCtx, *socket, rtCfg.statusID, rtCfg.faultID, rtCfg.wheelSpeedScalar, rtCfg.steeringFeedbackScalar, gen.state, logger)
+	}, gen.workers.Done)
+
+	return gen, nil
 }
 
-// constants from the data sheet.
-const (
-	channel        = "can0"
-	driveId uint32 = 0x220
-)
+// newBase creates a new base that underneath the hood sends canbus frames via
+// a 10ms publishing loop, and reads decoded feedback back off a receive loop.
+func newBase(conf config.Component, logger golog.Logger) (base.Base, error) {
+	intermodeConfig, ok := conf.ConvertedAttributes.(*Config)
+	if !ok {
+		return nil, utils.NewUnexpectedTypeError(&Config{}, conf.ConvertedAttributes)
+	}
+
+	gen, err := newGeneration(intermodeConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	iBase := &interModeBase{name: conf.Name, logger: logger}
+	iBase.gen.Store(gen)
+	return iBase, nil
+}
 
 const (
 	gearPark          = "park"
@@ -125,6 +307,12 @@ var (
 	}
 )
 
+// auxiliary command bit flags.
+const (
+	auxBitDoor byte = 1 << iota
+	auxBitLight
+)
+
 type driveCommand struct {
 	Accelerator   float64
 	Brake         float64
@@ -134,17 +322,16 @@ type driveCommand struct {
 }
 
 // calculateSteeringAngleBytes returns the intermode specific angle bytes for the given angle.
-func calculateSteeringAngleBytes(angle float64) []byte {
-	// angle from -90 to 90
+func calculateSteeringAngleBytes(angle, scalar, maxAngleDeg float64) []byte {
 	// positive is left, negative is right
-	if math.Abs(angle) > 90 {
+	if math.Abs(angle) > maxAngleDeg {
 		if math.Signbit(angle) {
-			angle = -90
+			angle = -maxAngleDeg
 		} else {
-			angle = 90
+			angle = maxAngleDeg
 		}
 	}
-	value := int16(angle / 0.0078125) // intermode scalar
+	value := int16(angle / scalar)
 
 	angleBytes := make([]byte, 2)
 	binary.LittleEndian.PutUint16(angleBytes, uint16(value))
@@ -152,18 +339,18 @@ func calculateSteeringAngleBytes(angle float64) []byte {
 }
 
 // calculateAccelAndBrakeBytes returns the intermode specific acceleration and brake bytes for the given
-// acceleration percentage.
-func calculateAccelAndBrakeBytes(accelPct float64) []byte {
+// acceleration percentage. maxSpeedPct is applied proportionally (scaledPct = accelPct * maxSpeedPct / 100),
+// the same safety nerf the original code hardcoded as accelPct /= 5, just with the divisor made configurable.
+func calculateAccelAndBrakeBytes(accelPct, scalar, maxSpeedPct float64) []byte {
 	if accelPct == 0 {
 		// 0 accel, 100 brake
 		// where 100 is 1600 because of the steps, which i believe is 0x0640 in hex
 		// but we flip our byte orders because the owner told us
 		return []byte{0, 0, 0x40, 0x06} // LE
 	}
-	accelPct = math.Abs(accelPct)
-	// nerf the speed to one fifth for safe office traversal
-	accelPct /= 5
-	value := uint16(accelPct / 0.0625) // intermode scalar
+	accelPct = math.Min(math.Abs(accelPct), 100)
+	scaledPct := accelPct * maxSpeedPct / 100
+	value := uint16(scaledPct / scalar)
 
 	acceloratorBytes := make([]byte, 4)
 	binary.LittleEndian.PutUint16(acceloratorBytes[:2], value)
@@ -171,22 +358,18 @@ func calculateAccelAndBrakeBytes(accelPct float64) []byte {
 }
 
 type modalCommand interface {
-	toFrame(logger golog.Logger) canbus.Frame
+	toFrame(logger golog.Logger, cfg *runtimeConfig) canbus.Frame
 }
 
 // toFrame convert the command to a canbus data frame.
-func (cmd *driveCommand) toFrame(logger golog.Logger) canbus.Frame {
+func (cmd *driveCommand) toFrame(logger golog.Logger, cfg *runtimeConfig) canbus.Frame {
 	frame := canbus.Frame{
-		ID:   driveId,
+		ID:   cfg.driveID,
 		Data: make([]byte, 0, 8),
 		Kind: canbus.SFF,
 	}
-	frame.Data = append(frame.Data, calculateAccelAndBrakeBytes(cmd.Accelerator)...)
-	frame.Data = append(frame.Data, calculateSteeringAngleBytes(cmd.SteeringAngle)...)
-	// is this the best place to be setting the gear to reverse? felt better than in each place that sets the forward motion.
-	if cmd.Accelerator < 0 {
-		cmd.Gear = gears[gearReverse]
-	}
+	frame.Data = append(frame.Data, calculateAccelAndBrakeBytes(cmd.Accelerator, cfg.accelScalar, cfg.maxSpeedPct)...)
+	frame.Data = append(frame.Data, calculateSteeringAngleBytes(cmd.SteeringAngle, cfg.steeringAngleScalar, cfg.maxSteeringAngleDeg)...)
 	frame.Data = append(frame.Data, cmd.Gear, cmd.SteerMode)
 
 	logger.Debugw("frame", "data", frame.Data)
@@ -194,25 +377,285 @@ func (cmd *driveCommand) toFrame(logger golog.Logger) canbus.Frame {
 	return frame
 }
 
+// driveState tracks the last commanded drive frame so DoCommand gear/steer-mode
+// changes can be applied without disturbing an in-flight Move/Spin call.
+type driveState struct {
+	mu  sync.Mutex
+	cmd driveCommand
+}
+
+func (d *driveState) get() driveCommand {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cmd
+}
+
+func (d *driveState) set(cmd driveCommand) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cmd = cmd
+}
+
+// auxCommand is a second modalCommand for the door/light auxiliary CAN message,
+// published on its own periodic cadence alongside the drive frame.
+type auxCommand struct {
+	flags byte
+}
+
+// toFrame converts the auxiliary command to a canbus data frame.
+func (cmd *auxCommand) toFrame(logger golog.Logger, cfg *runtimeConfig) canbus.Frame {
+	frame := canbus.Frame{
+		ID:   cfg.auxID,
+		Data: []byte{cmd.flags},
+		Kind: canbus.SFF,
+	}
+
+	logger.Debugw("aux frame", "data", frame.Data)
+
+	return frame
+}
+
+// auxState tracks the last commanded auxiliary frame so individual door/light bits
+// can be flipped without clobbering the others.
+type auxState struct {
+	mu  sync.Mutex
+	cmd auxCommand
+}
+
+func (a *auxState) get() auxCommand {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.cmd
+}
+
+func (a *auxState) set(cmd auxCommand) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cmd = cmd
+}
+
+// baseState holds the most recently decoded status/fault feedback frames, guarded
+// by a mutex since it's written from the receive goroutine and read from API calls.
+type baseState struct {
+	mu sync.Mutex
+
+	frontWheelSpeedMmPerSec float64
+	rearWheelSpeedMmPerSec  float64
+	odometryTick            uint16
+	actualGear              byte
+	actualSteeringAngleDeg  float64
+	faultFlags              uint8
+	updatedAt               time.Time
+}
+
+// statusSnapshot is a point-in-time copy of baseState safe to hand out to callers.
+type statusSnapshot struct {
+	FrontWheelSpeedMmPerSec float64
+	RearWheelSpeedMmPerSec  float64
+	OdometryTick            uint16
+	ActualGear              byte
+	ActualSteeringAngleDeg  float64
+	FaultFlags              uint8
+	UpdatedAt               time.Time
+}
+
+func (s *baseState) setStatus(frontSpeed, rearSpeed float64, tick uint16, gear byte, steeringAngle float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frontWheelSpeedMmPerSec = frontSpeed
+	s.rearWheelSpeedMmPerSec = rearSpeed
+	s.odometryTick = tick
+	s.actualGear = gear
+	s.actualSteeringAngleDeg = steeringAngle
+	s.updatedAt = time.Now()
+}
+
+func (s *baseState) setFaultFlags(flags uint8) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faultFlags = flags
+	s.updatedAt = time.Now()
+}
+
+func (s *baseState) isMoving() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.frontWheelSpeedMmPerSec != 0 || s.rearWheelSpeedMmPerSec != 0
+}
+
+func (s *baseState) snapshot() statusSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return statusSnapshot{
+		FrontWheelSpeedMmPerSec: s.frontWheelSpeedMmPerSec,
+		RearWheelSpeedMmPerSec:  s.rearWheelSpeedMmPerSec,
+		OdometryTick:            s.odometryTick,
+		ActualGear:              s.actualGear,
+		ActualSteeringAngleDeg:  s.actualSteeringAngleDeg,
+		FaultFlags:              s.faultFlags,
+		UpdatedAt:               s.updatedAt,
+	}
+}
+
+// decodeStatusFrame decodes the front/rear wheel speed, odometry tick, actual gear,
+// and actual steering angle out of a status feedback frame. The actual steering angle
+// is a single signed byte, so it's decoded with its own byte-sized scalar rather than
+// the 2-byte scalar used to encode the commanded angle.
+func decodeStatusFrame(data []byte, wheelSpeedScalar, steeringFeedbackScalar float64, state *baseState) {
+	if len(data) < 8 {
+		return
+	}
+	frontSpeed := float64(int16(binary.LittleEndian.Uint16(data[0:2]))) * wheelSpeedScalar
+	rearSpeed := float64(int16(binary.LittleEndian.Uint16(data[2:4]))) * wheelSpeedScalar
+	tick := binary.LittleEndian.Uint16(data[4:6])
+	gear := data[6]
+	steeringAngle := float64(int8(data[7])) * steeringFeedbackScalar
+	state.setStatus(frontSpeed, rearSpeed, tick, gear, steeringAngle)
+}
+
+// decodeFaultFrame decodes the fault flag bitfield out of a fault feedback frame.
+func decodeFaultFrame(data []byte, state *baseState) {
+	if len(data) < 1 {
+		return
+	}
+	state.setFaultFlags(data[0])
+}
+
+// generation bundles every piece of state tied to one bound CAN socket: the socket
+// itself, the runtime config it was built from, the background workers reading and
+// writing it, and the command/feedback state they share. Reconfigure swaps the whole
+// thing in as a single atomic unit instead of updating fields one at a time, so no
+// reader ever observes a mix of old and new generation state, and the old generation's
+// socket and background workers can be torn down cleanly once the swap is done.
+type generation struct {
+	cfg              *runtimeConfig
+	socket           *canbus.Socket
+	nextCommandCh    chan canbus.Frame
+	nextAuxCommandCh chan canbus.Frame
+	cancel           func()
+	workers          *sync.WaitGroup
+
+	state       *baseState
+	drive       *driveState
+	aux         *auxState
+	diagnostics map[uint32]*frameDiagnostics
+}
+
 type interModeBase struct {
-	name                    string
-	nextCommandCh           chan canbus.Frame
-	activeBackgroundWorkers sync.WaitGroup
-	cancel                  func()
-	logger                  golog.Logger
+	name   string
+	logger golog.Logger
+
+	// gen holds the current *generation, swapped atomically by Reconfigure so the hot
+	// send/receive paths never block on a lock and never see a torn mix of fields.
+	gen atomic.Value
 
 	// generic.Unimplemented is a helper that embeds an unimplemented error in the Do method.
 	generic.Unimplemented
 }
 
-// publishThread continuously sends the current command over the canbus.
+func (base *interModeBase) current() *generation {
+	return base.gen.Load().(*generation)
+}
+
+// Reconfigure swaps in the generation built by a freshly constructed instance, so the
+// CAN channel, frame IDs, scalars, and safety limits can change without restarting the
+// module. The swap is a single atomic pointer store; right after it, the old
+// generation's background workers are stopped and its CAN socket closed. Callers that
+// loaded the old generation before the swap are not waited for.
+func (base *interModeBase) Reconfigure(ctx context.Context, newResource resource.Resource) error {
+	next, ok := newResource.(*interModeBase)
+	if !ok {
+		return utils.NewUnexpectedTypeError(base, newResource)
+	}
+
+	old := base.current()
+	base.gen.Store(next.current())
+
+	// receiveThread blocks in a raw socket Recv with no deadline, so cancel alone
+	// won't unblock it on an idle bus; the socket has to be closed before (or
+	// concurrently with) waiting for the background workers to exit, not after.
+	old.cancel()
+	closeErr := old.socket.Close()
+	old.workers.Wait()
+	return closeErr
+}
+
+// frameSlot is one periodic CAN frame published at its own cadence: the drive
+// frame at 10ms, the auxiliary door/light frame at 100ms, and so on.
+type frameSlot struct {
+	id           uint32
+	interval     time.Duration
+	cmdCh        chan canbus.Frame
+	initialFrame canbus.Frame
+	diag         *frameDiagnostics
+}
+
+// frameDiagnostics tracks per-frame publish health so operators can see which CAN IDs
+// are unhealthy through DoCommand({"command":"diagnostics"}) without attaching candump.
+type frameDiagnostics struct {
+	mu       sync.Mutex
+	lastSent time.Time
+	lastErr  error
+	errCount int
+}
+
+func (d *frameDiagnostics) recordSuccess(at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastSent = at
+	d.lastErr = nil
+}
+
+func (d *frameDiagnostics) recordError(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.errCount++
+	d.lastErr = err
+}
+
+func (d *frameDiagnostics) snapshot() map[string]interface{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := map[string]interface{}{
+		"last_sent":   d.lastSent,
+		"error_count": d.errCount,
+	}
+	if d.lastErr != nil {
+		out["last_error"] = d.lastErr.Error()
+	}
+	return out
+}
+
+// publishThread fans out to one publish loop per configured frame slot via an
+// errgroup, so one slow/failing socket write doesn't stall the others.
 func publishThread(
 	ctx context.Context,
 	socket canbus.Socket,
-	nextCommandCh chan canbus.Frame,
+	slots []frameSlot,
 	logger golog.Logger,
 ) {
-	frame := (&stopCmd).toFrame(logger)
+	var eg errgroup.Group
+	for _, slot := range slots {
+		slot := slot
+		eg.Go(func() error {
+			publishFrameSlot(ctx, socket, slot, logger)
+			return nil
+		})
+	}
+	// publishFrameSlot only returns once ctx is done, so this just blocks until every
+	// frame's publish loop has exited; errors are recorded in slot.diag, not returned.
+	_ = eg.Wait()
+}
+
+// publishFrameSlot continuously sends the current command for a single frame slot
+// over the canbus, recording per-frame diagnostics as it goes.
+func publishFrameSlot(
+	ctx context.Context,
+	socket canbus.Socket,
+	slot frameSlot,
+	logger golog.Logger,
+) {
+	frame := slot.initialFrame
 
 	for {
 		if ctx.Err() != nil {
@@ -220,11 +663,45 @@ func publishThread(
 		}
 		select {
 		case <-ctx.Done():
-		case frame = <-nextCommandCh:
-		case <-time.After(10 * time.Millisecond):
+		case frame = <-slot.cmdCh:
+		case <-time.After(slot.interval):
 		}
 		if _, err := socket.Send(frame); err != nil {
-			logger.Errorw("send error", "error", err)
+			logger.Errorw("send error", "id", slot.id, "error", err)
+			slot.diag.recordError(err)
+			continue
+		}
+		slot.diag.recordSuccess(time.Now())
+	}
+}
+
+// receiveThread continuously reads CAN frames off the socket and decodes status/fault
+// feedback into the shared baseState so API calls can read it back out.
+func receiveThread(
+	ctx context.Context,
+	socket canbus.Socket,
+	statusId, faultId uint32,
+	wheelSpeedScalar, steeringFeedbackScalar float64,
+	state *baseState,
+	logger golog.Logger,
+) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		frame, err := socket.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Errorw("recv error", "error", err)
+			continue
+		}
+		switch frame.ID {
+		case statusId:
+			decodeStatusFrame(frame.Data, wheelSpeedScalar, steeringFeedbackScalar, state)
+		case faultId:
+			decodeFaultFrame(frame.Data, state)
 		}
 	}
 }
@@ -234,61 +711,155 @@ func publishThread(
 	Every method will set the next command for the publish loop to send over the command bus forever.
 */
 
-// MoveStraight moves the base forward the given distance and speed.
-func (base *interModeBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
-	cmd := driveCommand{
-		Accelerator:   50,
-		Brake:         0,
-		SteeringAngle: 0,
-		Gear:          gears[gearDrive],
-		SteerMode:     steerModes[steerModeFourWheelDrive],
-	}
+// PI gains for the closed-loop distance/angle control loops below, and the cadence
+// they re-evaluate at. Tuned empirically on the office loop course.
+const (
+	controlKP       = 0.8
+	controlKI       = 0.05
+	controlInterval = 20 * time.Millisecond
 
-	if mmPerSec < 0 || distanceMm < 0 {
-		cmd.Accelerator *= -1
-	}
+	// approximate Intermode wheelbase, used to turn measured forward speed and actual
+	// steering angle feedback into a yaw rate estimate for the Spin control loop.
+	wheelBaseMm = 1500.0
+)
 
-	if err := base.setNextCommand(ctx, &cmd); err != nil {
-		return err
+// driveGearForDirection returns gearDrive/gearReverse for a signed distance or speed,
+// rather than inferring direction from the sign of the accelerator byte.
+func driveGearForDirection(reverse bool) byte {
+	if reverse {
+		return gears[gearReverse]
 	}
+	return gears[gearDrive]
+}
 
-	defer base.setNextCommand(ctx, &stopCmd)
+// yawRateDegPerSec estimates the base's turning rate from measured forward speed and
+// actual steering angle feedback using a simple bicycle model.
+func yawRateDegPerSec(speedMmPerSec, steeringAngleDeg float64) float64 {
+	steeringRad := steeringAngleDeg * math.Pi / 180
+	return (speedMmPerSec / wheelBaseMm) * math.Tan(steeringRad) * 180 / math.Pi
+}
 
-	if !goutils.SelectContextOrWait(ctx, time.Duration(mmPerSec/float64(distanceMm))) {
-		return ctx.Err()
+// MoveStraight moves the base the given distance and speed (in reverse gear when either
+// is negative), closing the loop
+// on decoded wheel speed feedback until the integrated distance target is reached.
+func (base *interModeBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+	if distanceMm != 0 && mmPerSec == 0 {
+		return errors.New("mmPerSec must be non-zero to move a non-zero distance")
 	}
 
-	return nil
+	// use a fresh context here, not ctx: ctx is what's cancelled to trigger this
+	// defer, and setNextCommand bails out immediately on an already-cancelled
+	// context, so the stop frame would never actually get queued.
+	defer base.setNextCommand(context.Background(), &stopCmd)
+
+	targetMm := math.Abs(float64(distanceMm))
+	targetSpeed := math.Abs(mmPerSec)
+	reverse := distanceMm < 0 || mmPerSec < 0
+	gear := driveGearForDirection(reverse)
+
+	integrator := 0.0
+	traveledMm := 0.0
+	lastTick := time.Now()
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if traveledMm >= targetMm {
+			return nil
+		}
+
+		status := base.current().state.snapshot()
+		measuredSpeed := math.Abs((status.FrontWheelSpeedMmPerSec + status.RearWheelSpeedMmPerSec) / 2)
+
+		now := time.Now()
+		traveledMm += measuredSpeed * now.Sub(lastTick).Seconds()
+		lastTick = now
+
+		speedErr := targetSpeed - measuredSpeed
+		integrator += speedErr * controlInterval.Seconds()
+		accel := math.Max(0, math.Min(100, controlKP*speedErr+controlKI*integrator))
+
+		if err := base.setNextCommand(ctx, &driveCommand{
+			Accelerator: accel,
+			Gear:        gear,
+			SteerMode:   steerModes[steerModeFourWheelDrive],
+		}); err != nil {
+			return err
+		}
+
+		if !goutils.SelectContextOrWait(ctx, controlInterval) {
+			return ctx.Err()
+		}
+	}
 }
 
-// Spin spins the base by the given angleDeg and degsPerSec.
+// Spin spins the base by the given angleDeg and degsPerSec, closing the loop on a yaw
+// rate estimated from wheel speed and actual steering angle feedback.
 func (base *interModeBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
-	if err := base.setNextCommand(ctx, &driveCommand{
-		Accelerator:   50,
-		Brake:         0,
-		SteeringAngle: angleDeg,
-		Gear:          gears[gearDrive],
-		SteerMode:     steerModes[steerModeFourWheelDrive],
-	}); err != nil {
-		return err
+	if angleDeg != 0 && degsPerSec == 0 {
+		return errors.New("degsPerSec must be non-zero to spin a non-zero angle")
 	}
 
-	defer base.setNextCommand(ctx, &stopCmd)
+	// use a fresh context here, not ctx: ctx is what's cancelled to trigger this
+	// defer, and setNextCommand bails out immediately on an already-cancelled
+	// context, so the stop frame would never actually get queued.
+	defer base.setNextCommand(context.Background(), &stopCmd)
 
-	if !goutils.SelectContextOrWait(ctx, time.Duration(angleDeg/math.Abs(degsPerSec))) {
-		return ctx.Err()
-	}
+	targetDeg := math.Abs(angleDeg)
+	targetRate := math.Abs(degsPerSec)
+	turnRight := angleDeg < 0
 
-	return nil
+	integrator := 0.0
+	turnedDeg := 0.0
+	lastTick := time.Now()
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if turnedDeg >= targetDeg {
+			return nil
+		}
+
+		status := base.current().state.snapshot()
+		speed := (status.FrontWheelSpeedMmPerSec + status.RearWheelSpeedMmPerSec) / 2
+		measuredRate := math.Abs(yawRateDegPerSec(speed, status.ActualSteeringAngleDeg))
+
+		now := time.Now()
+		turnedDeg += measuredRate * now.Sub(lastTick).Seconds()
+		lastTick = now
+
+		rateErr := targetRate - measuredRate
+		integrator += rateErr * controlInterval.Seconds()
+		steeringAngle := math.Max(-90, math.Min(90, controlKP*rateErr+controlKI*integrator))
+		if turnRight {
+			steeringAngle *= -1
+		}
+
+		if err := base.setNextCommand(ctx, &driveCommand{
+			Accelerator:   50,
+			SteeringAngle: steeringAngle,
+			Gear:          gears[gearDrive],
+			SteerMode:     steerModes[steerModeFourWheelDrive],
+		}); err != nil {
+			return err
+		}
+
+		if !goutils.SelectContextOrWait(ctx, controlInterval) {
+			return ctx.Err()
+		}
+	}
 }
 
 // SetPower sets the linear and angular [-1, 1] drive power.
 func (base *interModeBase) SetPower(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+	accel := linear.Y * 100
 	return base.setNextCommand(ctx, &driveCommand{
-		Accelerator:   linear.Y * 100,
+		Accelerator:   math.Abs(accel),
 		Brake:         0,
 		SteeringAngle: angular.Z * 100,
-		Gear:          gears[gearDrive],
+		Gear:          driveGearForDirection(accel < 0),
 		SteerMode:     steerModes[steerModeFourWheelDrive],
 	})
 }
@@ -296,10 +867,10 @@ func (base *interModeBase) SetPower(ctx context.Context, linear, angular r3.Vect
 // SetVelocity sets the linear (mmPerSec) and angular (degsPerSec) velocity.
 func (base *interModeBase) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
 	return base.setNextCommand(ctx, &driveCommand{
-		Accelerator:   linear.Y,
+		Accelerator:   math.Abs(linear.Y),
 		Brake:         0,
 		SteeringAngle: angular.Z * 100,
-		Gear:          gears[gearDrive],
+		Gear:          driveGearForDirection(linear.Y < 0),
 		SteerMode:     steerModes[steerModeFourWheelDrive],
 	})
 }
@@ -317,18 +888,121 @@ func (base *interModeBase) Stop(ctx context.Context, extra map[string]interface{
 	return base.setNextCommand(ctx, &stopCmd)
 }
 
+// IsMoving reports true when the most recently decoded wheel speed feedback is non-zero.
 func (base *interModeBase) IsMoving(ctx context.Context) (bool, error) {
-	return false, utils.NewUnimplementedInterfaceError((*interModeBase)(nil), "intermodeBase does not yet support IsMoving()")
+	return base.current().state.isMoving(), nil
 }
 
-// DoCommand executes additional commands beyond the Base{} interface. For this rover that includes door open and close commands.
+// Status returns the most recently decoded status/fault feedback off the CAN bus.
+func (base *interModeBase) Status(ctx context.Context) (statusSnapshot, error) {
+	return base.current().state.snapshot(), nil
+}
+
+// DoCommand executes additional commands beyond the Base{} interface: the latest
+// decoded status, gear and steer-mode changes, emergency stop, door open/close,
+// switching the light on or off, and per-frame publish diagnostics.
 func (base *interModeBase) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
-	// TODO: expand this function to change steering/gearing modes.
 	name, ok := cmd["command"]
 	if !ok {
 		return nil, errors.New("missing 'command' value")
 	}
+	gen := base.current()
 	switch name {
+	case "status":
+		status, err := base.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"front_wheel_speed_mm_per_sec": status.FrontWheelSpeedMmPerSec,
+			"rear_wheel_speed_mm_per_sec":  status.RearWheelSpeedMmPerSec,
+			"odometry_tick":                status.OdometryTick,
+			"actual_gear":                  status.ActualGear,
+			"actual_steering_angle_deg":    status.ActualSteeringAngleDeg,
+			"fault_flags":                  status.FaultFlags,
+			"updated_at":                   status.UpdatedAt,
+		}, nil
+
+	case "set_gear":
+		gearName, ok := cmd["gear"].(string)
+		if !ok {
+			return nil, errors.New("set_gear requires a 'gear' string value")
+		}
+		gear, ok := gears[gearName]
+		if !ok {
+			return nil, fmt.Errorf("unknown gear: %s", gearName)
+		}
+		drive := gen.drive.get()
+		drive.Gear = gear
+		if err := base.setNextCommand(ctx, &drive); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"gear": gearName}, nil
+
+	case "set_steer_mode":
+		modeName, ok := cmd["steer_mode"].(string)
+		if !ok {
+			return nil, errors.New("set_steer_mode requires a 'steer_mode' string value")
+		}
+		mode, ok := steerModes[modeName]
+		if !ok {
+			return nil, fmt.Errorf("unknown steer mode: %s", modeName)
+		}
+		drive := gen.drive.get()
+		drive.SteerMode = mode
+		if err := base.setNextCommand(ctx, &drive); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"steer_mode": modeName}, nil
+
+	case "emergency_stop":
+		drive := gen.drive.get()
+		drive.Gear = gears[gearEmergencyStop]
+		drive.Accelerator = 0
+		drive.Brake = 100
+		if err := base.setNextCommand(ctx, &drive); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"gear": gearEmergencyStop}, nil
+
+	case "door_open":
+		aux := gen.aux.get()
+		aux.flags |= auxBitDoor
+		if err := base.setNextAuxCommand(ctx, &aux); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"door": "open"}, nil
+
+	case "door_close":
+		aux := gen.aux.get()
+		aux.flags &^= auxBitDoor
+		if err := base.setNextAuxCommand(ctx, &aux); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"door": "closed"}, nil
+
+	case "diagnostics":
+		out := make(map[string]interface{}, len(gen.diagnostics))
+		for id, diag := range gen.diagnostics {
+			out[fmt.Sprintf("0x%x", id)] = diag.snapshot()
+		}
+		return out, nil
+
+	case "light":
+		on, ok := cmd["on"].(bool)
+		if !ok {
+			return nil, errors.New("light requires an 'on' bool value")
+		}
+		aux := gen.aux.get()
+		if on {
+			aux.flags |= auxBitLight
+		} else {
+			aux.flags &^= auxBitLight
+		}
+		if err := base.setNextAuxCommand(ctx, &aux); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"light": on}, nil
 
 	default:
 		return nil, fmt.Errorf("no such command: %s", name)
@@ -337,18 +1011,41 @@ func (base *interModeBase) DoCommand(ctx context.Context, cmd map[string]interfa
 
 // Close cleanly closes the base.
 func (base *interModeBase) Close() {
-	base.cancel()
-	base.activeBackgroundWorkers.Wait()
+	gen := base.current()
+	gen.cancel()
+	closeErr := gen.socket.Close()
+	gen.workers.Wait()
+	if closeErr != nil {
+		base.logger.Errorw("error closing canbus socket", "error", closeErr)
+	}
 }
 
 func (base *interModeBase) setNextCommand(ctx context.Context, cmd modalCommand) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	gen := base.current()
+	if drive, ok := cmd.(*driveCommand); ok {
+		gen.drive.set(*drive)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case gen.nextCommandCh <- cmd.toFrame(base.logger, gen.cfg):
+	}
+	return nil
+}
+
+func (base *interModeBase) setNextAuxCommand(ctx context.Context, cmd *auxCommand) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	gen := base.current()
+	gen.aux.set(*cmd)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case base.nextCommandCh <- cmd.toFrame(base.logger):
+	case gen.nextAuxCommandCh <- cmd.toFrame(base.logger, gen.cfg):
 	}
 	return nil
 }
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/rdk/config"
+	"go.viam.com/test"
+)
+
+func TestConfigValidateDefaults(t *testing.T) {
+	cfg := &Config{}
+	warnings, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, warnings, test.ShouldBeNil)
+
+	test.That(t, cfg.Channel, test.ShouldEqual, defaultChannel)
+	test.That(t, cfg.DriveFrameID, test.ShouldEqual, defaultDriveID)
+	test.That(t, cfg.AuxFrameID, test.ShouldEqual, defaultAuxID)
+	test.That(t, cfg.StatusFrameID, test.ShouldEqual, defaultStatusID)
+	test.That(t, cfg.FaultFrameID, test.ShouldEqual, defaultFaultID)
+	test.That(t, cfg.SteeringAngleScalar, test.ShouldEqual, defaultSteeringAngleScalar)
+	test.That(t, cfg.SteeringFeedbackScalar, test.ShouldEqual, defaultSteeringFeedbackScalar)
+	test.That(t, cfg.AccelScalar, test.ShouldEqual, defaultAccelScalar)
+	test.That(t, cfg.WheelSpeedScalar, test.ShouldEqual, defaultWheelSpeedScalar)
+	test.That(t, cfg.MaxSteeringAngleDeg, test.ShouldEqual, defaultMaxSteeringAngleDeg)
+	test.That(t, cfg.MaxSpeedPct, test.ShouldEqual, defaultMaxSpeedPct)
+}
+
+func TestConfigValidateVirtualChannel(t *testing.T) {
+	cfg := &Config{Virtual: true}
+	_, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Channel, test.ShouldEqual, defaultVirtualChannel)
+}
+
+func TestConfigValidateRanges(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+	}{
+		{"steering angle too high", Config{MaxSteeringAngleDeg: 91}},
+		{"steering angle negative", Config{MaxSteeringAngleDeg: -1}},
+		{"speed pct too high", Config{MaxSpeedPct: 101}},
+		{"speed pct negative", Config{MaxSpeedPct: -1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			_, err := cfg.Validate("path")
+			test.That(t, err, test.ShouldNotBeNil)
+		})
+	}
+}
+
+func TestCalculateSteeringAngleBytes(t *testing.T) {
+	const scalar = defaultSteeringAngleScalar
+	const maxAngleDeg = 90.0
+
+	decode := func(data []byte) int16 {
+		return int16(binary.LittleEndian.Uint16(data))
+	}
+
+	straight := calculateSteeringAngleBytes(0, scalar, maxAngleDeg)
+	test.That(t, decode(straight), test.ShouldEqual, int16(0))
+
+	left := calculateSteeringAngleBytes(45, scalar, maxAngleDeg)
+	test.That(t, decode(left), test.ShouldEqual, int16(45/scalar))
+
+	// angles beyond the max clamp to +/- maxAngleDeg rather than wrapping.
+	clampedLeft := calculateSteeringAngleBytes(200, scalar, maxAngleDeg)
+	test.That(t, decode(clampedLeft), test.ShouldEqual, int16(maxAngleDeg/scalar))
+
+	clampedRight := calculateSteeringAngleBytes(-200, scalar, maxAngleDeg)
+	test.That(t, decode(clampedRight), test.ShouldEqual, int16(-maxAngleDeg/scalar))
+}
+
+func TestCalculateAccelAndBrakeBytes(t *testing.T) {
+	const scalar = defaultAccelScalar
+	const maxSpeedPct = 20.0
+
+	// zero accelerator commands full brake via the documented special-case bytes.
+	test.That(t, calculateAccelAndBrakeBytes(0, scalar, maxSpeedPct), test.ShouldResemble, []byte{0, 0, 0x40, 0x06})
+
+	decode := func(data []byte) uint16 {
+		return binary.LittleEndian.Uint16(data[:2])
+	}
+
+	// maxSpeedPct is applied proportionally, not as a hard ceiling: half the input
+	// should produce half the scaled output.
+	full := decode(calculateAccelAndBrakeBytes(100, scalar, maxSpeedPct))
+	half := decode(calculateAccelAndBrakeBytes(50, scalar, maxSpeedPct))
+	test.That(t, full, test.ShouldEqual, uint16(maxSpeedPct/scalar))
+	test.That(t, half, test.ShouldEqual, uint16((maxSpeedPct/2)/scalar))
+
+	// input beyond 100% clamps rather than scaling past the configured limit.
+	over := decode(calculateAccelAndBrakeBytes(500, scalar, maxSpeedPct))
+	test.That(t, over, test.ShouldEqual, full)
+}
+
+func TestDecodeStatusFrame(t *testing.T) {
+	const wheelSpeedScalar = 0.01
+	const steeringFeedbackScalar = 90.0 / 127.0
+
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint16(data[0:2], uint16(int16(500)))  // front speed
+	binary.LittleEndian.PutUint16(data[2:4], uint16(int16(-500))) // rear speed
+	binary.LittleEndian.PutUint16(data[4:6], 42)                  // odometry tick
+	data[6] = gears[gearDrive]                                    // actual gear
+	data[7] = byte(int8(-64))                                     // actual steering angle
+
+	state := &baseState{}
+	decodeStatusFrame(data, wheelSpeedScalar, steeringFeedbackScalar, state)
+
+	snapshot := state.snapshot()
+	test.That(t, snapshot.FrontWheelSpeedMmPerSec, test.ShouldEqual, 500*wheelSpeedScalar)
+	test.That(t, snapshot.RearWheelSpeedMmPerSec, test.ShouldEqual, -500*wheelSpeedScalar)
+	test.That(t, snapshot.OdometryTick, test.ShouldEqual, uint16(42))
+	test.That(t, snapshot.ActualGear, test.ShouldEqual, gears[gearDrive])
+	test.That(t, snapshot.ActualSteeringAngleDeg, test.ShouldEqual, -64*steeringFeedbackScalar)
+}
+
+func TestDecodeStatusFrameShort(t *testing.T) {
+	state := &baseState{}
+	decodeStatusFrame([]byte{1, 2, 3}, 0.01, 90.0/127.0, state)
+	test.That(t, state.snapshot(), test.ShouldResemble, statusSnapshot{})
+}
+
+func TestDecodeFaultFrame(t *testing.T) {
+	state := &baseState{}
+	decodeFaultFrame([]byte{0x05}, state)
+	test.That(t, state.snapshot().FaultFlags, test.ShouldEqual, uint8(0x05))
+}
+
+func TestDecodeFaultFrameEmpty(t *testing.T) {
+	state := &baseState{}
+	decodeFaultFrame(nil, state)
+	test.That(t, state.snapshot().FaultFlags, test.ShouldEqual, uint8(0))
+}
+
+// TestReconfigureVcan0 exercises newBase/Reconfigure/Close against a real vcan0
+// interface, skipping if one isn't available (e.g. no CAP_NET_ADMIN, vcan module not
+// loaded). Set one up locally or in CI with:
+//
+//	sudo modprobe vcan && sudo ip link add dev vcan0 type vcan && sudo ip link set up vcan0
+func TestReconfigureVcan0(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	newTestBase := func() *interModeBase {
+		cfg := &Config{Virtual: true}
+		if _, err := cfg.Validate("path"); err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+		b, err := newBase(config.Component{Name: "intermode-test", ConvertedAttributes: cfg}, logger)
+		if err != nil {
+			t.Skipf("vcan0 not available in this environment: %v", err)
+		}
+		return b.(*interModeBase)
+	}
+
+	iBase := newTestBase()
+	next := newTestBase()
+
+	withTimeout := func(name string, fn func() error) {
+		t.Helper()
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+		select {
+		case err := <-done:
+			test.That(t, err, test.ShouldBeNil)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s did not return within 5s", name)
+		}
+	}
+
+	withTimeout("Reconfigure", func() error {
+		return iBase.Reconfigure(context.Background(), next)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		iBase.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return within 5s")
+	}
+}